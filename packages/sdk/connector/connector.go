@@ -0,0 +1,68 @@
+// Package connector defines the pluggable source-format adapter interface
+// used by the ODIN Go SDK to move between wire formats (SWIFT MT, ISO 8583,
+// HL7, FIX, ...) and ODIN's normalized JSON representation without the
+// caller hand-massaging either side. It is modeled after dex's connector
+// registry: built-in adapters register a factory under a name, and callers
+// look adapters up by that name at runtime.
+package connector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Connector parses a raw source-format message into ODIN's normalized map
+// representation and serializes it back out again.
+type Connector interface {
+	// ID returns the connector's registered name.
+	ID() string
+	// Parse converts a raw wire-format message into a normalized map.
+	Parse(raw []byte) (map[string]interface{}, error)
+	// Serialize converts a normalized map back into the wire format.
+	Serialize(data map[string]interface{}) ([]byte, error)
+}
+
+// Factory builds a Connector instance from its JSON configuration.
+type Factory func(config json.RawMessage) (Connector, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register adds a connector factory under name. It panics on duplicate
+// registration.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("connector: factory already registered for %q", name))
+	}
+	factories[name] = factory
+}
+
+// Open builds a Connector for the given registered name.
+func Open(name string, config json.RawMessage) (Connector, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("connector: no factory registered for %q", name)
+	}
+	return factory(config)
+}
+
+// Registered returns the names of all currently registered connectors.
+func Registered() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+// Package fix implements an ODIN connector for FIX 4.4 tag=value messages.
+package fix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector"
+)
+
+// ID is the name this connector registers under.
+const ID = "fix.4.4"
+
+// sohDelimiter is the FIX field separator, SOH (0x01).
+const sohDelimiter = "\x01"
+
+func init() {
+	connector.Register(ID, func(config json.RawMessage) (connector.Connector, error) {
+		return &Connector{}, nil
+	})
+}
+
+// Connector parses and serializes FIX 4.4 tag=value messages.
+type Connector struct{}
+
+// ID implements connector.Connector.
+func (c *Connector) ID() string { return ID }
+
+// Parse splits a raw FIX message on SOH into tag=value fields. Repeated tags
+// (e.g. within repeating groups) are collapsed into a JSON array in
+// encounter order.
+func (c *Connector) Parse(raw []byte) (map[string]interface{}, error) {
+	text := strings.Trim(string(raw), sohDelimiter)
+	if text == "" {
+		return nil, fmt.Errorf("fix: empty message")
+	}
+
+	fields := map[string]interface{}{}
+	for _, part := range strings.Split(text, sohDelimiter) {
+		if part == "" {
+			continue
+		}
+		tag, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("fix: malformed field %q", part)
+		}
+
+		switch existing := fields[tag].(type) {
+		case nil:
+			fields[tag] = value
+		case []interface{}:
+			fields[tag] = append(existing, value)
+		default:
+			fields[tag] = []interface{}{existing, value}
+		}
+	}
+
+	if _, ok := fields["8"]; !ok {
+		return nil, fmt.Errorf("fix: message missing BeginString (tag 8)")
+	}
+
+	return fields, nil
+}
+
+// Serialize reconstructs a raw FIX message from its tag/value map.
+// BodyLength (9) and CheckSum (10) are left to the caller/transport to
+// recompute, since they depend on the final wire bytes.
+func (c *Connector) Serialize(data map[string]interface{}) ([]byte, error) {
+	beginString, ok := data["8"]
+	if !ok {
+		return nil, fmt.Errorf("fix: missing BeginString (tag 8)")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "8=%v%s", beginString, sohDelimiter)
+
+	for tag, value := range data {
+		if tag == "8" {
+			continue
+		}
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				fmt.Fprintf(&b, "%s=%v%s", tag, item, sohDelimiter)
+			}
+		default:
+			fmt.Fprintf(&b, "%s=%v%s", tag, v, sohDelimiter)
+		}
+	}
+
+	return []byte(b.String()), nil
+}
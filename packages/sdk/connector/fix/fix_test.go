@@ -0,0 +1,48 @@
+package fix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSerializeRoundTrip(t *testing.T) {
+	raw := []byte("8=FIX.4.4\x0135=D\x0149=SENDER\x0156=TARGET\x01")
+
+	c := &Connector{}
+	parsed, err := c.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	serialized, err := c.Serialize(parsed)
+	if err != nil {
+		t.Fatalf("Serialize returned error: %v", err)
+	}
+
+	reparsed, err := c.Parse(serialized)
+	if err != nil {
+		t.Fatalf("Parse of serialized message returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(parsed, reparsed) {
+		t.Fatalf("round-trip mismatch: original %#v, after serialize+reparse %#v", parsed, reparsed)
+	}
+}
+
+func TestParseRepeatedTag(t *testing.T) {
+	raw := []byte("8=FIX.4.4\x0135=D\x01453=2\x01448=A\x01448=B\x01")
+
+	c := &Connector{}
+	parsed, err := c.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	got, ok := parsed["448"].([]interface{})
+	if !ok {
+		t.Fatalf("expected repeated tag 448 to decode as []interface{}, got %T", parsed["448"])
+	}
+	if !reflect.DeepEqual(got, []interface{}{"A", "B"}) {
+		t.Fatalf("expected [A B], got %v", got)
+	}
+}
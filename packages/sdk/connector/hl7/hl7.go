@@ -0,0 +1,131 @@
+// Package hl7 implements an ODIN connector for HL7 v2 pipe-delimited
+// messages.
+package hl7
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector"
+)
+
+// ID is the name this connector registers under.
+const ID = "hl7.v2"
+
+func init() {
+	connector.Register(ID, func(config json.RawMessage) (connector.Connector, error) {
+		return &Connector{}, nil
+	})
+}
+
+// Connector parses and serializes HL7 v2 messages: segments separated by
+// carriage return, fields by `|`, and repeated/composite fields by `^`.
+type Connector struct{}
+
+// ID implements connector.Connector.
+func (c *Connector) ID() string { return ID }
+
+// Parse splits the message into segments keyed by segment ID (e.g. "MSH",
+// "OBX"), each holding a []interface{} of occurrences in message order (most
+// segment types appear once, but OBX/NTE/DG1/IN1 and others commonly repeat
+// within a single message). Each occurrence is itself a []interface{} of
+// that segment's fields, with `^`-delimited fields further split into
+// composite []interface{} components.
+func (c *Connector) Parse(raw []byte) (map[string]interface{}, error) {
+	text := strings.ReplaceAll(string(raw), "\r\n", "\r")
+	lines := strings.Split(strings.Trim(text, "\r\n"), "\r")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "MSH") {
+		return nil, fmt.Errorf("hl7: message does not start with an MSH segment")
+	}
+
+	segments := map[string]interface{}{}
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		segmentID := fields[0]
+
+		components := make([]interface{}, 0, len(fields)-1)
+		for _, field := range fields[1:] {
+			if strings.Contains(field, "^") {
+				parts := strings.Split(field, "^")
+				composite := make([]interface{}, len(parts))
+				for i, p := range parts {
+					composite[i] = p
+				}
+				components = append(components, composite)
+			} else {
+				components = append(components, field)
+			}
+		}
+
+		occurrences, _ := segments[segmentID].([]interface{})
+		segments[segmentID] = append(occurrences, components)
+	}
+
+	return segments, nil
+}
+
+// Serialize reconstructs a raw HL7 v2 message from its segment
+// representation, emitting MSH first (writing out each of its occurrences,
+// ordinarily just one) followed by the remaining segment IDs in whatever
+// order the map iterates, each with all of its occurrences in order.
+func (c *Connector) Serialize(data map[string]interface{}) ([]byte, error) {
+	msh, ok := data["MSH"]
+	if !ok {
+		return nil, fmt.Errorf("hl7: missing MSH segment")
+	}
+
+	var b strings.Builder
+	writeFields := func(id string, fields interface{}) error {
+		components, ok := fields.([]interface{})
+		if !ok {
+			return fmt.Errorf("hl7: segment %s has malformed fields", id)
+		}
+		b.WriteString(id)
+		for _, field := range components {
+			b.WriteString("|")
+			switch v := field.(type) {
+			case []interface{}:
+				parts := make([]string, len(v))
+				for i, p := range v {
+					parts[i] = fmt.Sprintf("%v", p)
+				}
+				b.WriteString(strings.Join(parts, "^"))
+			default:
+				fmt.Fprintf(&b, "%v", v)
+			}
+		}
+		b.WriteString("\r")
+		return nil
+	}
+
+	writeSegment := func(id string, occurrences interface{}) error {
+		occs, ok := occurrences.([]interface{})
+		if !ok {
+			return fmt.Errorf("hl7: segment %s has malformed occurrences", id)
+		}
+		for _, occ := range occs {
+			if err := writeFields(id, occ); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := writeSegment("MSH", msh); err != nil {
+		return nil, err
+	}
+	for id, occurrences := range data {
+		if id == "MSH" {
+			continue
+		}
+		if err := writeSegment(id, occurrences); err != nil {
+			return nil, err
+		}
+	}
+
+	return []byte(b.String()), nil
+}
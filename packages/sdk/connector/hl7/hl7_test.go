@@ -0,0 +1,75 @@
+package hl7
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSerializeRoundTrip(t *testing.T) {
+	raw := []byte("MSH|^~\\&|SENDER|FAC|RECEIVER|FAC|20240101120000||ADT^A01|MSG00001|P|2.3\rPID|1||12345^^^MRN||DOE^JOHN")
+
+	c := &Connector{}
+	parsed, err := c.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	serialized, err := c.Serialize(parsed)
+	if err != nil {
+		t.Fatalf("Serialize returned error: %v", err)
+	}
+
+	reparsed, err := c.Parse(serialized)
+	if err != nil {
+		t.Fatalf("Parse of serialized message returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(parsed, reparsed) {
+		t.Fatalf("round-trip mismatch: original %#v, after serialize+reparse %#v", parsed, reparsed)
+	}
+}
+
+// TestParsePreservesRepeatedSegments checks that a message with more than
+// one occurrence of the same segment type (OBX is the common case in a real
+// ORU message) keeps every occurrence instead of the last one silently
+// overwriting the rest.
+func TestParsePreservesRepeatedSegments(t *testing.T) {
+	raw := []byte("MSH|^~\\&|SENDER|FAC|RECEIVER|FAC|20240101120000||ORU^R01|MSG00001|P|2.3\r" +
+		"OBX|1|ST|TEST1||firstvalue\r" +
+		"OBX|2|ST|TEST2||secondvalue")
+
+	c := &Connector{}
+	parsed, err := c.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	obx, ok := parsed["OBX"].([]interface{})
+	if !ok {
+		t.Fatalf("expected OBX to decode as []interface{}, got %T", parsed["OBX"])
+	}
+	if len(obx) != 2 {
+		t.Fatalf("expected 2 OBX occurrences, got %d: %#v", len(obx), obx)
+	}
+
+	first, ok := obx[0].([]interface{})
+	if !ok || len(first) < 5 || first[4] != "firstvalue" {
+		t.Fatalf("first OBX occurrence missing or wrong, got %#v", obx[0])
+	}
+	second, ok := obx[1].([]interface{})
+	if !ok || len(second) < 5 || second[4] != "secondvalue" {
+		t.Fatalf("second OBX occurrence missing or wrong, got %#v", obx[1])
+	}
+
+	serialized, err := c.Serialize(parsed)
+	if err != nil {
+		t.Fatalf("Serialize returned error: %v", err)
+	}
+	reparsed, err := c.Parse(serialized)
+	if err != nil {
+		t.Fatalf("Parse of serialized message returned error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, reparsed) {
+		t.Fatalf("round-trip mismatch: original %#v, after serialize+reparse %#v", parsed, reparsed)
+	}
+}
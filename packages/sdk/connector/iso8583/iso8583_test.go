@@ -0,0 +1,52 @@
+package iso8583
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSerializeRoundTrip(t *testing.T) {
+	// Bitmap 0x32 sets bits 3 (processing code), 4 (amount), and 7
+	// (transmission date & time) — all fixed-length fields this connector
+	// supports. Field 2 (the LLVAR-encoded primary account number) is
+	// deliberately not used here: this connector doesn't implement
+	// LLVAR/LLLVAR length-prefix parsing.
+	raw := []byte("0200" +
+		"3200000000000000" +
+		"000000" +
+		"000000001000" +
+		"2401011200")
+
+	c := &Connector{}
+	parsed, err := c.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	serialized, err := c.Serialize(parsed)
+	if err != nil {
+		t.Fatalf("Serialize returned error: %v", err)
+	}
+
+	reparsed, err := c.Parse(serialized)
+	if err != nil {
+		t.Fatalf("Parse of serialized message returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(parsed, reparsed) {
+		t.Fatalf("round-trip mismatch: original %#v, after serialize+reparse %#v", parsed, reparsed)
+	}
+}
+
+// TestParseRejectsField2 checks that a message carrying field 2 (the
+// LLVAR-encoded primary account number) is rejected rather than silently
+// misparsed as a fixed-width field.
+func TestParseRejectsField2(t *testing.T) {
+	// Bitmap 0x40 sets only bit 2.
+	raw := []byte("0200" + "4000000000000000" + "1919999999999999999")
+
+	c := &Connector{}
+	if _, err := c.Parse(raw); err == nil {
+		t.Fatal("expected Parse to reject a message carrying the unsupported LLVAR field 2, got nil error")
+	}
+}
@@ -0,0 +1,104 @@
+// Package swift implements an ODIN connector for SWIFT MT messages.
+package swift
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector"
+)
+
+// ID is the name this connector registers under.
+const ID = "swift.mt"
+
+func init() {
+	connector.Register(ID, func(config json.RawMessage) (connector.Connector, error) {
+		return &Connector{}, nil
+	})
+}
+
+// blockPattern matches one SWIFT block: `{<id>:<body>}`, where body may
+// itself contain nested `{...}` (blocks 3 and 5 carry tag/value sub-blocks).
+var blockPattern = regexp.MustCompile(`\{(\d):((?:[^{}]|\{[^{}]*\})*)\}`)
+
+// fieldPattern matches one tag-line within block 4, e.g. `:20:REF12345`.
+var fieldPattern = regexp.MustCompile(`(?m)^:(\d{2}[A-Z]?):(.*(?:\n[^:\n-][^\n]*)*)`)
+
+// Connector parses and serializes SWIFT MT messages (blocks 1-5).
+type Connector struct{}
+
+// ID implements connector.Connector.
+func (c *Connector) ID() string { return ID }
+
+// Parse splits a raw MT message into its blocks and, for block 4 (the text
+// block, where the business fields live), its tag structure.
+func (c *Connector) Parse(raw []byte) (map[string]interface{}, error) {
+	matches := blockPattern.FindAllStringSubmatch(string(raw), -1)
+	if matches == nil {
+		return nil, fmt.Errorf("swift: no SWIFT blocks found in message")
+	}
+
+	blocks := map[string]interface{}{}
+	for _, m := range matches {
+		blockID, body := m[1], m[2]
+
+		switch blockID {
+		case "4":
+			blocks["block4"] = parseFields(body)
+		default:
+			blocks["block"+blockID] = strings.TrimSpace(body)
+		}
+	}
+
+	if _, ok := blocks["block4"]; !ok {
+		return nil, fmt.Errorf("swift: message has no block 4 (text block)")
+	}
+
+	return blocks, nil
+}
+
+// parseFields extracts `:tag:value` fields from a block 4 body, where value
+// may continue on following lines until the next `:tag:` marker. Values are
+// stored as interface{} (rather than string) so the result is the same
+// map[string]interface{} shape Serialize expects for block4, letting a
+// Parse result round-trip through Serialize unmodified.
+func parseFields(body string) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, m := range fieldPattern.FindAllStringSubmatch(body, -1) {
+		tag := m[1]
+		value := strings.TrimSpace(strings.ReplaceAll(m[2], "\n", " "))
+		fields[tag] = value
+	}
+	return fields
+}
+
+// Serialize reconstructs a raw MT message from its block representation.
+func (c *Connector) Serialize(data map[string]interface{}) ([]byte, error) {
+	var b strings.Builder
+
+	for _, blockID := range []string{"1", "2", "3"} {
+		body, ok := data["block"+blockID].(string)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "{%s:%s}", blockID, body)
+	}
+
+	fields, ok := data["block4"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("swift: missing block4 fields")
+	}
+	b.WriteString("{4:\n")
+	for tag, value := range fields {
+		fmt.Fprintf(&b, ":%s:%v\n", tag, value)
+	}
+	b.WriteString("-}")
+
+	if body, ok := data["block5"].(string); ok {
+		fmt.Fprintf(&b, "{5:%s}", body)
+	}
+
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,33 @@
+package swift
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseThenSerialize checks that a Parse result can be fed straight back
+// into Serialize without error. block4 previously came back from Parse as
+// map[string]string while Serialize only accepted map[string]interface{},
+// so this failed a type assertion before parseFields was fixed to match.
+func TestParseThenSerialize(t *testing.T) {
+	raw := []byte("{1:F01BANKDEFFAXXX0000000000}{2:I103BANKDEFFXXXXN}{4:\n:20:REF12345\n-}")
+
+	c := &Connector{}
+	parsed, err := c.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	serialized, err := c.Serialize(parsed)
+	if err != nil {
+		t.Fatalf("Serialize returned error: %v", err)
+	}
+
+	out := string(serialized)
+	if !strings.Contains(out, "{1:F01BANKDEFFAXXX0000000000}") {
+		t.Fatalf("serialized message missing block1: %q", out)
+	}
+	if !strings.Contains(out, ":20:REF12345") {
+		t.Fatalf("serialized message missing block4 field 20: %q", out)
+	}
+}
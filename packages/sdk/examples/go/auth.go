@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AuthProvider injects credentials onto an outgoing request. It supersedes
+// the static OdinClient.APIKey for deployments that front ODIN with an
+// enterprise IdP.
+type AuthProvider interface {
+	// Authorize sets whatever headers the provider needs (typically
+	// Authorization: Bearer <token>) on req.
+	Authorize(ctx context.Context, req *http.Request) error
+}
+
+// oidcDiscoveryDoc is the subset of /.well-known/openid-configuration this
+// client cares about.
+type oidcDiscoveryDoc struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+	JWKSURI       string `json:"jwks_uri"`
+}
+
+// OIDCFlow selects how OIDCAuthProvider obtains tokens.
+type OIDCFlow int
+
+const (
+	OIDCFlowClientCredentials OIDCFlow = iota
+	OIDCFlowAuthorizationCode
+)
+
+// OIDCAuthProvider is an AuthProvider that discovers an OIDC issuer, obtains
+// tokens via client-credentials or authorization-code, and refreshes them
+// ahead of expiry.
+type OIDCAuthProvider struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Flow         OIDCFlow
+
+	// AuthorizationCode and RedirectURI are required when Flow is
+	// OIDCFlowAuthorizationCode; the code is exchanged once and the
+	// resulting refresh token (if any) is used thereafter.
+	AuthorizationCode string
+	RedirectURI       string
+
+	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	discovery   *oidcDiscoveryDoc
+	accessToken string
+	expiresAt   time.Time
+	refreshTok  string
+}
+
+// NewOIDCAuthProvider builds an OIDCAuthProvider for the client-credentials
+// flow, the common case for service-to-service ODIN access.
+func NewOIDCAuthProvider(issuerURL, clientID, clientSecret string, scopes ...string) *OIDCAuthProvider {
+	return &OIDCAuthProvider{
+		IssuerURL:    issuerURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Flow:         OIDCFlowClientCredentials,
+		HTTPClient:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Authorize implements AuthProvider.
+func (p *OIDCAuthProvider) Authorize(ctx context.Context, req *http.Request) error {
+	token, err := p.token(ctx)
+	if err != nil {
+		return fmt.Errorf("oidc: failed to obtain token: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// token returns a cached access token, refreshing it if it is missing or
+// within 30 seconds of expiry.
+func (p *OIDCAuthProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Until(p.expiresAt) > 30*time.Second {
+		return p.accessToken, nil
+	}
+
+	doc, err := p.discoveryDoc(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover issuer: %v", err)
+	}
+
+	form := url.Values{}
+	switch p.Flow {
+	case OIDCFlowAuthorizationCode:
+		if p.refreshTok != "" {
+			form.Set("grant_type", "refresh_token")
+			form.Set("refresh_token", p.refreshTok)
+		} else {
+			form.Set("grant_type", "authorization_code")
+			form.Set("code", p.AuthorizationCode)
+			form.Set("redirect_uri", p.RedirectURI)
+		}
+	default:
+		form.Set("grant_type", "client_credentials")
+	}
+	form.Set("client_id", p.ClientID)
+	form.Set("client_secret", p.ClientSecret)
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %v", err)
+	}
+
+	p.accessToken = tokenResp.AccessToken
+	if tokenResp.RefreshToken != "" {
+		p.refreshTok = tokenResp.RefreshToken
+	}
+	p.expiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	return p.accessToken, nil
+}
+
+// discoveryDoc fetches and caches the issuer's OIDC discovery document.
+func (p *OIDCAuthProvider) discoveryDoc(ctx context.Context) (*oidcDiscoveryDoc, error) {
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.IssuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %v", err)
+	}
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("discovery request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery endpoint returned status %d", resp.StatusCode)
+	}
+
+	var doc oidcDiscoveryDoc
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %v", err)
+	}
+
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+// JWKSVerifier validates JWS-signed payloads against an issuer's JWKS,
+// tracking key rotation via the `kid` header.
+type JWKSVerifier struct {
+	JWKSURI    string
+	HTTPClient *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]jwt.Keyfunc
+	fetched time.Time
+	maxAge  time.Duration
+}
+
+// NewJWKSVerifier builds a JWKSVerifier for the given JWKS endpoint.
+func NewJWKSVerifier(jwksURI string) *JWKSVerifier {
+	return &JWKSVerifier{
+		JWKSURI:    jwksURI,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+		maxAge:     10 * time.Minute,
+	}
+}
+
+// VerifySignature validates a compact JWS (RS256, ES256, or EdDSA) produced
+// by the ODIN server, such as the `signature` field on
+// SFTTranslationResponse or a VerifyProofChain payload.
+func (v *JWKSVerifier) VerifySignature(signature string) (*jwt.Token, error) {
+	keyfunc, err := v.keyfunc()
+	if err != nil {
+		return nil, fmt.Errorf("jwks: failed to load keys: %v", err)
+	}
+
+	token, err := jwt.Parse(signature, keyfunc, jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}))
+	if err != nil {
+		return nil, fmt.Errorf("jwks: signature verification failed: %v", err)
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("jwks: signature is not valid")
+	}
+
+	return token, nil
+}
+
+// keyfunc returns a jwt.Keyfunc backed by the cached JWKS, refreshing it if
+// stale or if it's asked for a `kid` it doesn't recognize (covers rotation).
+func (v *JWKSVerifier) keyfunc() (jwt.Keyfunc, error) {
+	if err := v.refreshIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		v.mu.Lock()
+		fn, ok := v.keys[kid]
+		v.mu.Unlock()
+
+		if !ok {
+			// Key rotated since our last fetch: force a refresh and retry once.
+			if err := v.refresh(); err != nil {
+				return nil, err
+			}
+			v.mu.Lock()
+			fn, ok = v.keys[kid]
+			v.mu.Unlock()
+			if !ok {
+				return nil, fmt.Errorf("unknown key id %q", kid)
+			}
+		}
+		return fn(token)
+	}, nil
+}
+
+func (v *JWKSVerifier) refreshIfNeeded() error {
+	v.mu.Lock()
+	stale := v.fetched.IsZero() || time.Since(v.fetched) > v.maxAge
+	v.mu.Unlock()
+	if stale {
+		return v.refresh()
+	}
+	return nil
+}
+
+// refresh fetches the JWKS document and rebuilds the kid -> key lookup.
+func (v *JWKSVerifier) refresh() error {
+	resp, err := v.HTTPClient.Get(v.JWKSURI)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS endpoint returned status %d", resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %v", err)
+	}
+
+	keys := make(map[string]jwt.Keyfunc, len(jwks.Keys))
+	for _, raw := range jwks.Keys {
+		var jwk jsonWebKey
+		if err := json.Unmarshal(raw, &jwk); err != nil {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		parsedKey := key
+		keys[jwk.Kid] = func(*jwt.Token) (interface{}, error) { return parsedKey, nil }
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetched = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+// jsonWebKey is the subset of RFC 7517 a JWKS entry needs for signature
+// verification: an RSA or EC public key, or an OKP (Ed25519) key.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC and OKP
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// publicKey decodes the JWK into the concrete public key type jwt.Parse
+// expects for the corresponding algorithm family.
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64URLBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid RSA modulus: %v", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid RSA exponent: %v", err)
+		}
+		e := 0
+		for _, b := range eBytes {
+			e = e<<8 | int(b)
+		}
+		return &rsa.PublicKey{N: n, E: e}, nil
+
+	case "EC":
+		curve, err := ecCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := base64URLBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid EC x coordinate: %v", err)
+		}
+		y, err := base64URLBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid EC y coordinate: %v", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("jwk: unsupported OKP curve %q", k.Crv)
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("jwk: invalid Ed25519 x: %v", err)
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwk: Ed25519 key has wrong length %d", len(raw))
+		}
+		return ed25519.PublicKey(raw), nil
+
+	default:
+		return nil, fmt.Errorf("jwk: unsupported key type %q", k.Kty)
+	}
+}
+
+// ecCurve maps a JWK "crv" value to its Go elliptic.Curve.
+func ecCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("jwk: unsupported EC curve %q", crv)
+	}
+}
+
+// base64URLBigInt decodes a base64url (no padding) JWK field into a big.Int.
+func base64URLBigInt(s string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
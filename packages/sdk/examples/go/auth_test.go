@@ -0,0 +1,107 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJSONWebKeyPublicKeyRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	jwk := jsonWebKey{
+		Kty: "RSA",
+		Kid: "rsa-1",
+		N:   base64.RawURLEncoding.EncodeToString(priv.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(priv.PublicKey.E)).Bytes()),
+	}
+
+	key, err := jwk.publicKey()
+	if err != nil {
+		t.Fatalf("publicKey returned error: %v", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		t.Fatalf("expected *rsa.PublicKey, got %T", key)
+	}
+	if rsaKey.N.Cmp(priv.PublicKey.N) != 0 || rsaKey.E != priv.PublicKey.E {
+		t.Fatalf("decoded RSA key does not match original")
+	}
+}
+
+func TestJSONWebKeyPublicKeyEd25519(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	jwk := jsonWebKey{
+		Kty: "OKP",
+		Kid: "ed-1",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(pub),
+	}
+
+	key, err := jwk.publicKey()
+	if err != nil {
+		t.Fatalf("publicKey returned error: %v", err)
+	}
+	if !key.(ed25519.PublicKey).Equal(pub) {
+		t.Fatalf("decoded Ed25519 key does not match original")
+	}
+}
+
+func TestJWKSVerifierVerifySignature(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	jwk := jsonWebKey{
+		Kty: "RSA",
+		Kid: "rsa-1",
+		N:   base64.RawURLEncoding.EncodeToString(priv.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(priv.PublicKey.E)).Bytes()),
+	}
+	raw, err := json.Marshal(jwk)
+	if err != nil {
+		t.Fatalf("failed to marshal jwk: %v", err)
+	}
+
+	v := &JWKSVerifier{
+		keys:    map[string]jwt.Keyfunc{},
+		maxAge:  time.Hour,
+		fetched: time.Now(),
+	}
+	var decoded jsonWebKey
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("failed to round-trip jwk: %v", err)
+	}
+	pub, err := decoded.publicKey()
+	if err != nil {
+		t.Fatalf("publicKey returned error: %v", err)
+	}
+	v.keys[jwk.Kid] = func(*jwt.Token) (interface{}, error) { return pub, nil }
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "odin"})
+	token.Header["kid"] = jwk.Kid
+	signed, err := token.SignedString(priv)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	if _, err := v.VerifySignature(signed); err != nil {
+		t.Fatalf("VerifySignature returned error for a validly signed token: %v", err)
+	}
+}
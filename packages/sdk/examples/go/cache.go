@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrCIDMismatch is returned when a translation's content hash doesn't
+// match its claimed CID, whether recomputed locally from a cache entry or
+// from the server's response.
+var ErrCIDMismatch = errors.New("odin: CID mismatch")
+
+// CIDCache stores SFTTranslationResponse values keyed by a canonical hash of
+// their (mapID, sourceData) request, so repeated translations (common in
+// Bridge Pro batch runs) are effectively free while still re-verifying CID
+// integrity on every hit.
+type CIDCache interface {
+	Get(key string) (*SFTTranslationResponse, bool)
+	Set(key string, resp *SFTTranslationResponse)
+	// Purge evicts entries older than olderThan.
+	Purge(olderThan time.Duration)
+}
+
+// cacheEntry pairs a cached response with the time it was stored, for
+// Purge's age check and MemoryCache's LRU eviction.
+type cacheEntry struct {
+	key      string
+	resp     *SFTTranslationResponse
+	storedAt time.Time
+}
+
+// MemoryCache is an in-process CIDCache with LRU eviction above maxEntries.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+// NewMemoryCache builds a MemoryCache that evicts least-recently-used
+// entries once it holds more than maxEntries.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get implements CIDCache.
+func (m *MemoryCache) Get(key string) (*SFTTranslationResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).resp, true
+}
+
+// Set implements CIDCache.
+func (m *MemoryCache) Set(key string, resp *SFTTranslationResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*cacheEntry).resp = resp
+		elem.Value.(*cacheEntry).storedAt = time.Now()
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	elem := m.order.PushFront(&cacheEntry{key: key, resp: resp, storedAt: time.Now()})
+	m.entries[key] = elem
+
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		if oldest == nil {
+			break
+		}
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Purge implements CIDCache.
+func (m *MemoryCache) Purge(olderThan time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	for elem := m.order.Back(); elem != nil; {
+		prev := elem.Prev()
+		entry := elem.Value.(*cacheEntry)
+		if entry.storedAt.Before(cutoff) {
+			m.order.Remove(elem)
+			delete(m.entries, entry.key)
+		}
+		elem = prev
+	}
+}
+
+// DiskCache is a CIDCache backed by one JSON file per entry under dir,
+// defaulting to $XDG_CACHE_HOME/odin (or ~/.cache/odin). Like MemoryCache,
+// it evicts least-recently-used entries once it holds more than maxEntries,
+// using each file's mtime (touched on every Get) as the recency signal.
+type DiskCache struct {
+	dir        string
+	maxEntries int
+
+	mu sync.Mutex
+}
+
+// NewDiskCache builds a DiskCache rooted at $XDG_CACHE_HOME/odin (or
+// ~/.cache/odin if XDG_CACHE_HOME is unset), creating it if necessary. It
+// evicts least-recently-used entries once it holds more than maxEntries; a
+// maxEntries of 0 disables eviction.
+func NewDiskCache(maxEntries int) (*DiskCache, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve home directory: %v", err)
+		}
+		base = filepath.Join(home, ".cache")
+	}
+
+	dir := filepath.Join(base, "odin")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	return &DiskCache{dir: dir, maxEntries: maxEntries}, nil
+}
+
+type diskCacheEntry struct {
+	Response *SFTTranslationResponse `json:"response"`
+	StoredAt time.Time               `json:"stored_at"`
+}
+
+// Get implements CIDCache.
+func (d *DiskCache) Get(key string) (*SFTTranslationResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return entry.Response, true
+}
+
+// Set implements CIDCache.
+func (d *DiskCache) Set(key string, resp *SFTTranslationResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry := diskCacheEntry{Response: resp, StoredAt: time.Now()}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(d.path(key), data, 0o600); err != nil {
+		return
+	}
+
+	d.evictLocked()
+}
+
+// evictLocked removes the least-recently-used entries (oldest mtime first)
+// once the cache directory holds more than maxEntries files. d.mu must be
+// held by the caller.
+func (d *DiskCache) evictLocked() {
+	if d.maxEntries <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(d.dir)
+	if err != nil || len(entries) <= d.maxEntries {
+		return
+	}
+
+	type fileInfo struct {
+		path    string
+		modTime time.Time
+	}
+	files := make([]fileInfo, 0, len(entries))
+	for _, dirEntry := range entries {
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{path: filepath.Join(d.dir, dirEntry.Name()), modTime: info.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	for _, f := range files[:len(files)-d.maxEntries] {
+		os.Remove(f.path)
+	}
+}
+
+// Purge implements CIDCache.
+func (d *DiskCache) Purge(olderThan time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	for _, dirEntry := range entries {
+		path := filepath.Join(d.dir, dirEntry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var entry diskCacheEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		if entry.StoredAt.Before(cutoff) {
+			os.Remove(path)
+		}
+	}
+}
+
+func (d *DiskCache) path(key string) string {
+	return filepath.Join(d.dir, key+".json")
+}
+
+// cidCacheKey computes a canonical JSON hash of (mapID, sourceData) to use
+// as the cache key for a translation request.
+func cidCacheKey(mapID string, sourceData map[string]interface{}) (string, error) {
+	canonical, err := canonicalizeJSON(map[string]interface{}{
+		"map_id":      mapID,
+		"source_data": sourceData,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize cache key: %v", err)
+	}
+	return ComputeDataHash(json.RawMessage(canonical))
+}
+
+// canonicalizeJSON produces a deterministic JSON encoding of v by sorting
+// object keys at every level, so identical data always hashes the same way
+// regardless of map iteration order.
+func canonicalizeJSON(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, decoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical recursively writes v to buf with object keys sorted.
+func writeCanonical(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			keyJSON, err := json.Marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(keyJSON)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, val[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+	return nil
+}
+
+// cidFor recomputes the CID (sha256 of the canonical JSON encoding) of a
+// translated_data payload, for comparison against a claimed CID.
+func cidFor(translatedData map[string]interface{}) (string, error) {
+	canonical, err := canonicalizeJSON(translatedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize translated data: %v", err)
+	}
+	return ComputeDataHash(json.RawMessage(canonical))
+}
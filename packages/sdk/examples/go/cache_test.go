@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func newTestDiskCache(t *testing.T, maxEntries int) *DiskCache {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := NewDiskCache(maxEntries)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	return cache
+}
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newTestDiskCache(t, 2)
+
+	cache.Set("a", &SFTTranslationResponse{CID: "a"})
+	cache.Set("b", &SFTTranslationResponse{CID: "b"})
+
+	// Touch "a" so it is more recently used than "b".
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("expected entry %q to be present", "a")
+	}
+
+	cache.Set("c", &SFTTranslationResponse{CID: "c"})
+
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil {
+		t.Fatalf("failed to read cache dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries after eviction, got %d", len(entries))
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf("expected least-recently-used entry %q to have been evicted", "b")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("expected recently-used entry %q to survive eviction", "a")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Fatalf("expected newly-written entry %q to survive eviction", "c")
+	}
+}
+
+func TestDiskCacheUnboundedWhenMaxEntriesZero(t *testing.T) {
+	cache := newTestDiskCache(t, 0)
+
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, &SFTTranslationResponse{CID: key})
+	}
+
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil {
+		t.Fatalf("failed to read cache dir: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected no eviction with maxEntries=0, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector"
+
+	// Register the built-in connectors so sourceConnectorID/targetConnectorID
+	// names like "swift.mt" and "iso8583" resolve without callers having to
+	// import each adapter package themselves.
+	_ "github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector/fix"
+	_ "github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector/hl7"
+	_ "github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector/iso8583"
+	_ "github.com/Maverick0351a/ODINAIMESH/packages/sdk/connector/swift"
+)
+
+// ExecuteBridgeProWorkflowFromRaw is the connector-aware overload of
+// ExecuteBridgeProWorkflow: it parses raw wire-format bytes (e.g. a SWIFT
+// MT103) via the registered sourceConnectorID, submits the normalized map to
+// the workflow, and re-serializes the result via targetConnectorID (e.g.
+// "iso8583" or a future "iso20022" connector) so callers never have to
+// hand-massage either side.
+func (c *OdinClient) ExecuteBridgeProWorkflowFromRaw(ctx context.Context, workflowID string, raw []byte, sourceConnectorID, targetConnectorID string) ([]byte, error) {
+	source, err := connector.Open(sourceConnectorID, json.RawMessage(nil))
+	if err != nil {
+		return nil, fmt.Errorf("bridge pro: failed to open source connector %q: %v", sourceConnectorID, err)
+	}
+
+	normalized, err := source.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("bridge pro: failed to parse source payload: %v", err)
+	}
+
+	result, err := c.ExecuteBridgeProWorkflow(ctx, workflowID, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := connector.Open(targetConnectorID, json.RawMessage(nil))
+	if err != nil {
+		return nil, fmt.Errorf("bridge pro: failed to open target connector %q: %v", targetConnectorID, err)
+	}
+
+	serialized, err := target.Serialize(result)
+	if err != nil {
+		return nil, fmt.Errorf("bridge pro: failed to serialize result: %v", err)
+	}
+
+	return serialized, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// deadlineTimer implements independently adjustable read/write deadlines for
+// a long-lived stream, modeled after the netstack `deadlineTimer` pattern:
+// each direction gets its own cancel channel and `time.AfterFunc` timer, so
+// extending or clearing a deadline never requires tearing down the
+// underlying connection.
+type deadlineTimer struct {
+	mu sync.Mutex
+
+	readTimer   *time.Timer
+	readCancel  chan struct{}
+	readChanged chan struct{}
+
+	writeTimer  *time.Timer
+	writeCancel chan struct{}
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{
+		readCancel:  make(chan struct{}),
+		readChanged: make(chan struct{}),
+		writeCancel: make(chan struct{}),
+	}
+}
+
+// SetReadDeadline arranges for readCancelChan() to be closed at t, replacing
+// any previously scheduled read deadline. A zero t clears it. Because every
+// call replaces the channel readCancelChan() returns, a goroutine that is
+// already blocked in a select on the old channel would otherwise never learn
+// about the change; readChangedChan() is closed here too so it wakes up,
+// re-reads readCancelChan(), and starts selecting on the current one.
+func (d *deadlineTimer) SetReadDeadline(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.readTimer != nil {
+		d.readTimer.Stop()
+	}
+	d.readCancel = make(chan struct{})
+
+	if t.IsZero() {
+		d.readTimer = nil
+	} else {
+		ch := d.readCancel
+		d.readTimer = time.AfterFunc(time.Until(t), func() { close(ch) })
+	}
+
+	close(d.readChanged)
+	d.readChanged = make(chan struct{})
+}
+
+// SetWriteDeadline arranges for writeCancelChan() to be closed at t,
+// replacing any previously scheduled write deadline. A zero t clears it.
+func (d *deadlineTimer) SetWriteDeadline(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.writeTimer != nil {
+		d.writeTimer.Stop()
+	}
+	d.writeCancel = make(chan struct{})
+
+	if t.IsZero() {
+		d.writeTimer = nil
+		return
+	}
+
+	ch := d.writeCancel
+	d.writeTimer = time.AfterFunc(time.Until(t), func() { close(ch) })
+}
+
+// readCancelChan returns the channel that closes when the current read
+// deadline expires.
+func (d *deadlineTimer) readCancelChan() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.readCancel
+}
+
+// readChangedChan returns a channel that closes as soon as SetReadDeadline
+// is next called, so a caller selecting on readCancelChan() can wake up and
+// fetch the replacement instead of being stuck selecting on a channel that a
+// concurrent SetReadDeadline call has already superseded.
+func (d *deadlineTimer) readChangedChan() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.readChanged
+}
+
+// writeCancelChan returns the channel that closes when the current write
+// deadline expires.
+func (d *deadlineTimer) writeCancelChan() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.writeCancel
+}
+
+// StreamingResponse is returned by ODIN's streaming and long-poll methods
+// (SFTTranslateStream, ExecuteBridgeProWorkflowStream, GetResearchResultsStream).
+// Unlike the HTTPClient.Timeout ceiling used for one-shot requests, a
+// StreamingResponse lets callers extend or shorten read/write deadlines for
+// the lifetime of the stream without reconnecting.
+type StreamingResponse struct {
+	*deadlineTimer
+
+	// Events delivers parsed SSE frames until the stream ends.
+	Events <-chan Event
+	// Errs carries transport-level failures; buffered with capacity 1.
+	Errs <-chan error
+
+	cancel context.CancelFunc
+}
+
+// StreamOption configures a StreamingResponse at creation time.
+type StreamOption func(*StreamingResponse)
+
+// WithDeadline sets both the read and write deadline.
+func WithDeadline(t time.Time) StreamOption {
+	return func(s *StreamingResponse) {
+		s.SetReadDeadline(t)
+		s.SetWriteDeadline(t)
+	}
+}
+
+// WithReadDeadline sets only the read deadline.
+func WithReadDeadline(t time.Time) StreamOption {
+	return func(s *StreamingResponse) { s.SetReadDeadline(t) }
+}
+
+// WithWriteDeadline sets only the write deadline.
+func WithWriteDeadline(t time.Time) StreamOption {
+	return func(s *StreamingResponse) { s.SetWriteDeadline(t) }
+}
+
+// Close tears down the stream's underlying connection.
+func (s *StreamingResponse) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
@@ -1,14 +1,12 @@
-"""
-ODIN Protocol Go SDK Example
-
-Demonstrates how to interact with ODIN Protocol services using Go.
-Shows SFT translation, Bridge Pro workflows, and Research Engine integration.
-"""
-
+// ODIN Protocol Go SDK Example
+//
+// Demonstrates how to interact with ODIN Protocol services using Go.
+// Shows SFT translation, Bridge Pro workflows, and Research Engine integration.
 package main
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -23,6 +21,23 @@ type OdinClient struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// OHTTP, when non-nil, routes every request through an Oblivious HTTP
+	// relay/gateway instead of calling BaseURL directly. See ohttp.go.
+	OHTTP *TransportOHTTP
+
+	// Auth, when set, takes priority over the static APIKey. See auth.go.
+	Auth AuthProvider
+
+	// JWKSVerifier, when set, validates the `signature` field returned by
+	// SFTTranslate and VerifyProofChain as a real JWS instead of treating it
+	// as opaque. See auth.go.
+	JWKSVerifier *JWKSVerifier
+
+	// Cache, when set, short-circuits SFTTranslate for a (mapID, sourceData)
+	// pair it has already seen, re-verifying CID integrity on every hit. See
+	// cache.go.
+	Cache CIDCache
 }
 
 type SFTTranslationRequest struct {
@@ -67,9 +82,23 @@ func NewOdinClient(baseURL, apiKey string) *OdinClient {
 }
 
 // makeRequest makes an HTTP request to the ODIN API
-func (c *OdinClient) makeRequest(method, endpoint string, body interface{}) (*http.Response, error) {
+func (c *OdinClient) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	req, err := c.buildRequest(ctx, method, endpoint, body, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	return c.doRequest(req)
+}
+
+// buildRequest constructs a request against endpoint, marshaling body (if
+// any) as JSON and applying whichever authentication is configured: an
+// AuthProvider (e.g. OIDCAuthProvider) takes priority over the static
+// APIKey. Shared by makeRequest and the streaming methods in stream.go so
+// AuthProvider/OHTTP configuration applies uniformly to every request the
+// client makes.
+func (c *OdinClient) buildRequest(ctx context.Context, method, endpoint string, body interface{}, accept string) (*http.Request, error) {
 	var reqBody io.Reader
-	
+
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
@@ -77,49 +106,109 @@ func (c *OdinClient) makeRequest(method, endpoint string, body interface{}) (*ht
 		}
 		reqBody = bytes.NewBuffer(jsonData)
 	}
-	
-	req, err := http.NewRequest(method, c.BaseURL+endpoint, reqBody)
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
-	
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	if c.APIKey != "" {
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", accept)
+	if c.Auth != nil {
+		if err := c.Auth.Authorize(req.Context(), req); err != nil {
+			return nil, fmt.Errorf("failed to authorize request: %v", err)
+		}
+	} else if c.APIKey != "" {
 		req.Header.Set("Authorization", "Bearer "+c.APIKey)
 	}
-	
+
+	return req, nil
+}
+
+// doRequest dispatches req through the OHTTP transport when configured,
+// otherwise over the plain HTTPClient.
+func (c *OdinClient) doRequest(req *http.Request) (*http.Response, error) {
+	if c.OHTTP != nil {
+		return c.OHTTP.Do(req)
+	}
 	return c.HTTPClient.Do(req)
 }
 
-// SFTTranslate performs SFT translation
-func (c *OdinClient) SFTTranslate(mapID string, sourceData map[string]interface{}) (*SFTTranslationResponse, error) {
+// SFTTranslate performs SFT translation. If c.Cache is set, a prior
+// translation of the same (mapID, sourceData) is returned without a network
+// call, after re-verifying that its CID still matches its translated_data.
+func (c *OdinClient) SFTTranslate(ctx context.Context, mapID string, sourceData map[string]interface{}) (*SFTTranslationResponse, error) {
+	var cacheKey string
+	if c.Cache != nil {
+		var err error
+		cacheKey, err = cidCacheKey(mapID, sourceData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute cache key: %v", err)
+		}
+
+		if cached, ok := c.Cache.Get(cacheKey); ok {
+			if err := verifyCID(cached); err != nil {
+				return nil, err
+			}
+			return cached, nil
+		}
+	}
+
 	request := SFTTranslationRequest{
 		MapID:      mapID,
 		SourceData: sourceData,
 	}
-	
-	resp, err := c.makeRequest("POST", "/sft/translate", request)
+
+	resp, err := c.makeRequest(ctx, "POST", "/sft/translate", request)
 	if err != nil {
 		return nil, fmt.Errorf("SFT translation request failed: %v", err)
 	}
 	defer resp.Body.Close()
-	
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("SFT translation failed with status: %d", resp.StatusCode)
 	}
-	
+
 	var result SFTTranslationResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode SFT response: %v", err)
 	}
-	
+
+	if c.JWKSVerifier != nil && result.Signature != "" {
+		if _, err := c.JWKSVerifier.VerifySignature(result.Signature); err != nil {
+			return nil, fmt.Errorf("SFT response signature verification failed: %v", err)
+		}
+	}
+
+	if err := verifyCID(&result); err != nil {
+		return nil, err
+	}
+
+	if c.Cache != nil {
+		c.Cache.Set(cacheKey, &result)
+	}
+
 	return &result, nil
 }
 
+// verifyCID independently recomputes sha256(canonicalize(translated_data))
+// and rejects resp if it doesn't match the claimed CID.
+func verifyCID(resp *SFTTranslationResponse) error {
+	computed, err := cidFor(resp.TranslatedData)
+	if err != nil {
+		return fmt.Errorf("failed to verify CID: %v", err)
+	}
+	if computed != resp.CID {
+		return fmt.Errorf("%w: expected %s, computed %s", ErrCIDMismatch, resp.CID, computed)
+	}
+	return nil
+}
+
 // GetBridgeProWorkflow retrieves Bridge Pro workflow details
-func (c *OdinClient) GetBridgeProWorkflow(workflowID string) (*BridgeProWorkflow, error) {
-	resp, err := c.makeRequest("GET", "/bridge-pro/workflows/"+workflowID, nil)
+func (c *OdinClient) GetBridgeProWorkflow(ctx context.Context, workflowID string) (*BridgeProWorkflow, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/bridge-pro/workflows/"+workflowID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Bridge Pro workflow request failed: %v", err)
 	}
@@ -138,13 +227,13 @@ func (c *OdinClient) GetBridgeProWorkflow(workflowID string) (*BridgeProWorkflow
 }
 
 // ExecuteBridgeProWorkflow executes a Bridge Pro workflow
-func (c *OdinClient) ExecuteBridgeProWorkflow(workflowID string, inputData map[string]interface{}) (map[string]interface{}, error) {
+func (c *OdinClient) ExecuteBridgeProWorkflow(ctx context.Context, workflowID string, inputData map[string]interface{}) (map[string]interface{}, error) {
 	request := map[string]interface{}{
 		"workflow_id": workflowID,
 		"input_data":  inputData,
 	}
-	
-	resp, err := c.makeRequest("POST", "/bridge-pro/execute", request)
+
+	resp, err := c.makeRequest(ctx, "POST", "/bridge-pro/execute", request)
 	if err != nil {
 		return nil, fmt.Errorf("Bridge Pro execution request failed: %v", err)
 	}
@@ -163,14 +252,14 @@ func (c *OdinClient) ExecuteBridgeProWorkflow(workflowID string, inputData map[s
 }
 
 // CreateResearchProject creates a new research project
-func (c *OdinClient) CreateResearchProject(title, description string, parameters map[string]interface{}) (*ResearchProject, error) {
+func (c *OdinClient) CreateResearchProject(ctx context.Context, title, description string, parameters map[string]interface{}) (*ResearchProject, error) {
 	request := map[string]interface{}{
 		"title":       title,
 		"description": description,
 		"parameters":  parameters,
 	}
-	
-	resp, err := c.makeRequest("POST", "/research/projects", request)
+
+	resp, err := c.makeRequest(ctx, "POST", "/research/projects", request)
 	if err != nil {
 		return nil, fmt.Errorf("Research project creation failed: %v", err)
 	}
@@ -189,8 +278,8 @@ func (c *OdinClient) CreateResearchProject(title, description string, parameters
 }
 
 // GetResearchResults retrieves research project results
-func (c *OdinClient) GetResearchResults(projectID string) (map[string]interface{}, error) {
-	resp, err := c.makeRequest("GET", "/research/projects/"+projectID+"/results", nil)
+func (c *OdinClient) GetResearchResults(ctx context.Context, projectID string) (map[string]interface{}, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/research/projects/"+projectID+"/results", nil)
 	if err != nil {
 		return nil, fmt.Errorf("Research results request failed: %v", err)
 	}
@@ -209,8 +298,8 @@ func (c *OdinClient) GetResearchResults(projectID string) (map[string]interface{
 }
 
 // VerifyProofChain verifies a cryptographic proof chain
-func (c *OdinClient) VerifyProofChain(proofData map[string]interface{}) (bool, error) {
-	resp, err := c.makeRequest("POST", "/verify-proof", proofData)
+func (c *OdinClient) VerifyProofChain(ctx context.Context, proofData map[string]interface{}) (bool, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/verify-proof", proofData)
 	if err != nil {
 		return false, fmt.Errorf("Proof verification request failed: %v", err)
 	}
@@ -229,7 +318,17 @@ func (c *OdinClient) VerifyProofChain(proofData map[string]interface{}) (bool, e
 	if !ok {
 		return false, fmt.Errorf("invalid verification response format")
 	}
-	
+
+	if c.JWKSVerifier != nil {
+		signature, _ := result["signature"].(string)
+		if signature == "" {
+			return false, fmt.Errorf("verification response missing signature")
+		}
+		if _, err := c.JWKSVerifier.VerifySignature(signature); err != nil {
+			return false, fmt.Errorf("proof chain signature verification failed: %v", err)
+		}
+	}
+
 	return valid, nil
 }
 
@@ -258,7 +357,9 @@ func main() {
 		"payment_type":  "credit_card",
 	}
 	
-	translation, err := client.SFTTranslate("payment_iso20022", sourceData)
+	ctx := context.Background()
+
+	translation, err := client.SFTTranslate(ctx, "payment_iso20022", sourceData)
 	if err != nil {
 		fmt.Printf("SFT translation failed: %v\n", err)
 	} else {
@@ -267,7 +368,7 @@ func main() {
 	
 	// Example 2: Bridge Pro Workflow
 	fmt.Println("\n=== Bridge Pro Workflow Example ===")
-	workflow, err := client.GetBridgeProWorkflow("swift_iso20022_conversion")
+	workflow, err := client.GetBridgeProWorkflow(ctx, "swift_iso20022_conversion")
 	if err != nil {
 		fmt.Printf("Bridge Pro workflow retrieval failed: %v\n", err)
 	} else {
@@ -279,7 +380,7 @@ func main() {
 			"target_format": "iso20022",
 		}
 		
-		result, err := client.ExecuteBridgeProWorkflow(workflow.WorkflowID, inputData)
+		result, err := client.ExecuteBridgeProWorkflow(ctx, workflow.WorkflowID, inputData)
 		if err != nil {
 			fmt.Printf("Bridge Pro execution failed: %v\n", err)
 		} else {
@@ -297,6 +398,7 @@ func main() {
 	}
 	
 	project, err := client.CreateResearchProject(
+		ctx,
 		"Payment Fraud Detection",
 		"ML model for detecting fraudulent payment transactions",
 		parameters,
@@ -310,7 +412,7 @@ func main() {
 		fmt.Println("Waiting for research results...")
 		time.Sleep(5 * time.Second)
 		
-		results, err := client.GetResearchResults(project.ProjectID)
+		results, err := client.GetResearchResults(ctx, project.ProjectID)
 		if err != nil {
 			fmt.Printf("Research results retrieval failed: %v\n", err)
 		} else {
@@ -340,7 +442,7 @@ func main() {
 			"timestamp": time.Now().Unix(),
 		}
 		
-		valid, err := client.VerifyProofChain(proofData)
+		valid, err := client.VerifyProofChain(ctx, proofData)
 		if err != nil {
 			fmt.Printf("Proof verification failed: %v\n", err)
 		} else {
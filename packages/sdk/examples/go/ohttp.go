@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/circl/hpke"
+)
+
+// wellKnownOHTTPGateway is the path gateways publish their HPKE key config on.
+const wellKnownOHTTPGateway = "/.well-known/ohttp-gateway"
+
+const (
+	ohttpRequestContentType  = "message/ohttp-req"
+	ohttpResponseContentType = "message/ohttp-res"
+)
+
+// ohttpSuite is the HPKE suite RFC 9458 gateways typically advertise:
+// DHKEM(X25519, HKDF-SHA256) / HKDF-SHA256 / AES-128-GCM.
+var ohttpSuite = hpke.NewSuite(hpke.KEM_X25519_HKDF_SHA256, hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM)
+
+// ohttpKeyConfig is a gateway's published HPKE key config: a key ID plus the
+// raw public key bytes for ohttpSuite's KEM.
+type ohttpKeyConfig struct {
+	KeyID     byte
+	PublicKey []byte
+}
+
+// TransportOHTTP routes requests through an Oblivious HTTP (RFC 9458)
+// relay/gateway pair so the relay never learns the caller's identity and the
+// gateway never learns the caller's IP. It is wired in via OdinClient.OHTTP.
+type TransportOHTTP struct {
+	RelayURL   string
+	GatewayURL string
+	HTTPClient *http.Client
+
+	mu        sync.Mutex
+	keyConfig ohttpKeyConfig
+	fetchedAt time.Time
+	maxAge    time.Duration
+}
+
+// NewTransportOHTTP builds a TransportOHTTP for the given relay and gateway.
+// The gateway's key config is fetched lazily on first use and re-fetched
+// once the cached copy is older than one hour; a key rotation within that
+// hour is not detected.
+func NewTransportOHTTP(relayURL, gatewayURL string) *TransportOHTTP {
+	return &TransportOHTTP{
+		RelayURL:   relayURL,
+		GatewayURL: gatewayURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		maxAge:     1 * time.Hour,
+	}
+}
+
+// Do encapsulates req as a binary HTTP message (RFC 9292), seals it with
+// HPKE under the gateway's key config, and POSTs the encapsulated request to
+// the relay. The decrypted gateway response is reconstructed as an
+// *http.Response.
+func (t *TransportOHTTP) Do(req *http.Request) (*http.Response, error) {
+	cfg, err := t.keyConfigFor()
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to fetch gateway key config: %v", err)
+	}
+
+	binary, err := encodeBinaryHTTPRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to encode binary HTTP request: %v", err)
+	}
+
+	kemID, _, _ := ohttpSuite.Params()
+	pubKey, err := kemID.Scheme().UnmarshalBinaryPublicKey(cfg.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to parse gateway public key: %v", err)
+	}
+
+	sender, err := ohttpSuite.NewSender(pubKey, ohttpInfo(cfg.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to build HPKE sender: %v", err)
+	}
+
+	enc, sealer, err := sender.Setup(nil)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to set up HPKE sealer: %v", err)
+	}
+
+	sealed, err := sealer.Seal(binary, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to seal request: %v", err)
+	}
+
+	var encapReq bytes.Buffer
+	encapReq.WriteByte(cfg.KeyID)
+	encapReq.Write(enc)
+	encapReq.Write(sealed)
+
+	relayReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, t.RelayURL, bytes.NewReader(encapReq.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to create relay request: %v", err)
+	}
+	relayReq.Header.Set("Content-Type", ohttpRequestContentType)
+
+	relayResp, err := t.HTTPClient.Do(relayReq)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: relay request failed: %v", err)
+	}
+	defer relayResp.Body.Close()
+
+	if relayResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ohttp: relay returned status %d", relayResp.StatusCode)
+	}
+	if ct := relayResp.Header.Get("Content-Type"); ct != "" && ct != ohttpResponseContentType {
+		return nil, fmt.Errorf("ohttp: unexpected relay content-type %q", ct)
+	}
+
+	encapResp, err := io.ReadAll(relayResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to read relay response: %v", err)
+	}
+
+	opener := ohttpResponseOpener(sealer, enc)
+	binaryResp, err := opener(encapResp)
+	if err != nil {
+		return nil, fmt.Errorf("ohttp: failed to decrypt gateway response: %v", err)
+	}
+
+	return decodeBinaryHTTPResponse(binaryResp, req)
+}
+
+// keyConfigFor returns the cached gateway key config, fetching it on first
+// use and re-fetching it once it is older than t.maxAge.
+func (t *TransportOHTTP) keyConfigFor() (ohttpKeyConfig, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.fetchedAt.IsZero() || time.Since(t.fetchedAt) > t.maxAge {
+		resp, err := t.HTTPClient.Get(t.GatewayURL + wellKnownOHTTPGateway)
+		if err != nil {
+			return ohttpKeyConfig{}, fmt.Errorf("failed to reach gateway: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return ohttpKeyConfig{}, fmt.Errorf("gateway returned status %d", resp.StatusCode)
+		}
+
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return ohttpKeyConfig{}, fmt.Errorf("failed to read gateway key config: %v", err)
+		}
+
+		cfg, err := parseOHTTPKeyConfig(raw)
+		if err != nil {
+			return ohttpKeyConfig{}, fmt.Errorf("failed to parse gateway key config: %v", err)
+		}
+
+		t.keyConfig = cfg
+		t.fetchedAt = time.Now()
+	}
+
+	return t.keyConfig, nil
+}
+
+// parseOHTTPKeyConfig parses the RFC 9458 Key Config wire format: a 1-byte
+// key ID followed by the KEM/KDF/AEAD-identified public key. This client
+// only ever requests ohttpSuite, so the public key is assumed to be sized
+// for that suite.
+func parseOHTTPKeyConfig(raw []byte) (ohttpKeyConfig, error) {
+	if len(raw) < 1 {
+		return ohttpKeyConfig{}, fmt.Errorf("key config too short")
+	}
+	return ohttpKeyConfig{KeyID: raw[0], PublicKey: raw[1:]}, nil
+}
+
+// ohttpInfo builds the HPKE `info` parameter RFC 9458 requires: the
+// "message/bhttp request" label followed by the key ID byte.
+func ohttpInfo(keyID byte) []byte {
+	info := append([]byte("message/bhttp request"), 0x00)
+	info = append(info, keyID)
+	return info
+}
+
+// ohttpResponseOpener derives the symmetric response key/nonce from the
+// request's exported secret (per RFC 9458 section 4.4) and returns a
+// function that decrypts an encapsulated response.
+func ohttpResponseOpener(sealer hpke.Sealer, enc []byte) func([]byte) ([]byte, error) {
+	_, _, aeadID := ohttpSuite.Params()
+
+	return func(encapResp []byte) ([]byte, error) {
+		secret := sealer.Export(append(enc, []byte("message/bhttp response")...), uint(aeadID.KeySize()))
+		aead, err := aeadID.New(secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build response AEAD: %v", err)
+		}
+
+		nonceSize := int(aeadID.NonceSize())
+		if len(encapResp) < nonceSize {
+			return nil, fmt.Errorf("encapsulated response too short")
+		}
+		nonce, ciphertext := encapResp[:nonceSize], encapResp[nonceSize:]
+		return aead.Open(nil, nonce, ciphertext, nil)
+	}
+}
+
+// encodeBinaryHTTPRequest serializes an *http.Request into the binary HTTP
+// message format defined by RFC 9292 (known-length request form).
+func encodeBinaryHTTPRequest(req *http.Request) ([]byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %v", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	authority := req.URL.Host
+	if authority == "" {
+		authority = req.Host
+	}
+
+	var buf bytes.Buffer
+	writeBHTTPString(&buf, req.Method)
+	writeBHTTPString(&buf, "https")
+	writeBHTTPString(&buf, authority)
+	writeBHTTPString(&buf, req.URL.RequestURI())
+
+	var headerBuf bytes.Buffer
+	for key, values := range req.Header {
+		for _, v := range values {
+			writeBHTTPString(&headerBuf, key)
+			writeBHTTPString(&headerBuf, v)
+		}
+	}
+	writeBHTTPVarint(&buf, uint64(headerBuf.Len()))
+	buf.Write(headerBuf.Bytes())
+
+	writeBHTTPVarint(&buf, uint64(len(body)))
+	buf.Write(body)
+	writeBHTTPVarint(&buf, 0) // no trailers
+
+	return buf.Bytes(), nil
+}
+
+// decodeBinaryHTTPResponse parses a binary HTTP response message (RFC 9292,
+// known-length response form) back into an *http.Response bound to the
+// original request.
+func decodeBinaryHTTPResponse(raw []byte, req *http.Request) (*http.Response, error) {
+	r := bufio.NewReader(bytes.NewReader(raw))
+
+	statusCode, err := readBHTTPVarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status code: %v", err)
+	}
+
+	headerLen, err := readBHTTPVarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header length: %v", err)
+	}
+	headerBytes := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, headerBytes); err != nil {
+		return nil, fmt.Errorf("failed to read headers: %v", err)
+	}
+
+	header := make(http.Header)
+	hr := bufio.NewReader(bytes.NewReader(headerBytes))
+	for hr.Buffered() > 0 || peekAvailable(hr) {
+		key, err := readBHTTPString(hr)
+		if err != nil {
+			break
+		}
+		value, err := readBHTTPString(hr)
+		if err != nil {
+			break
+		}
+		header.Add(textproto.CanonicalMIMEHeaderKey(key), value)
+	}
+
+	bodyLen, err := readBHTTPVarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body length: %v", err)
+	}
+	body := make([]byte, bodyLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	return &http.Response{
+		Status:     http.StatusText(int(statusCode)),
+		StatusCode: int(statusCode),
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func peekAvailable(r *bufio.Reader) bool {
+	_, err := r.Peek(1)
+	return err == nil
+}
+
+// writeBHTTPVarint writes v as a QUIC-style variable-length integer, the
+// length-prefix encoding RFC 9292 uses throughout.
+func writeBHTTPVarint(buf *bytes.Buffer, v uint64) {
+	switch {
+	case v < 1<<6:
+		buf.WriteByte(byte(v))
+	case v < 1<<14:
+		tmp := make([]byte, 2)
+		binary.BigEndian.PutUint16(tmp, uint16(v)|0x4000)
+		buf.Write(tmp)
+	case v < 1<<30:
+		tmp := make([]byte, 4)
+		binary.BigEndian.PutUint32(tmp, uint32(v)|0x80000000)
+		buf.Write(tmp)
+	default:
+		tmp := make([]byte, 8)
+		binary.BigEndian.PutUint64(tmp, v|0xC000000000000000)
+		buf.Write(tmp)
+	}
+}
+
+func readBHTTPVarint(r *bufio.Reader) (uint64, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	length := 1 << (first >> 6)
+	rest := make([]byte, length-1)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return 0, err
+	}
+
+	v := uint64(first & 0x3f)
+	for _, b := range rest {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func writeBHTTPString(buf *bytes.Buffer, s string) {
+	writeBHTTPVarint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+func readBHTTPString(r *bufio.Reader) (string, error) {
+	n, err := readBHTTPVarint(r)
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestEncodeBinaryHTTPRequestUsesURLHost(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://gateway.example.com/sft/translate", bytes.NewReader([]byte(`{"map_id":"x"}`)))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	binary, err := encodeBinaryHTTPRequest(req)
+	if err != nil {
+		t.Fatalf("encodeBinaryHTTPRequest returned error: %v", err)
+	}
+
+	if !bytes.Contains(binary, []byte("gateway.example.com")) {
+		t.Fatalf("encoded request is missing the authority derived from req.URL.Host: %q", binary)
+	}
+
+	// The request body must still be readable after encoding (it is
+	// re-wrapped so the caller's *http.Request stays usable).
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("failed to re-read request body: %v", err)
+	}
+	if string(body) != `{"map_id":"x"}` {
+		t.Fatalf("request body was consumed: got %q", body)
+	}
+}
+
+func TestDecodeBinaryHTTPResponseRoundTrip(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://gateway.example.com/", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	var buf bytes.Buffer
+	writeBHTTPVarint(&buf, 200)
+
+	var headerBuf bytes.Buffer
+	writeBHTTPString(&headerBuf, "Content-Type")
+	writeBHTTPString(&headerBuf, "application/json")
+	writeBHTTPVarint(&buf, uint64(headerBuf.Len()))
+	buf.Write(headerBuf.Bytes())
+
+	body := []byte(`{"cid":"abc"}`)
+	writeBHTTPVarint(&buf, uint64(len(body)))
+	buf.Write(body)
+
+	resp, err := decodeBinaryHTTPResponse(buf.Bytes(), req)
+	if err != nil {
+		t.Fatalf("decodeBinaryHTTPResponse returned error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Fatalf("expected Content-Type application/json, got %q", got)
+	}
+
+	decodedBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read decoded body: %v", err)
+	}
+	if string(decodedBody) != string(body) {
+		t.Fatalf("expected body %q, got %q", body, decodedBody)
+	}
+}
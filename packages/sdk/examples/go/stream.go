@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventType enumerates the `event:` names ODIN's streaming endpoints emit.
+type EventType string
+
+const (
+	EventPartial  EventType = "partial"
+	EventProgress EventType = "progress"
+	EventProof    EventType = "proof"
+	EventDone     EventType = "done"
+	EventError    EventType = "error"
+)
+
+// Event is a single server-sent event parsed from a streaming response.
+type Event struct {
+	Type EventType
+	Data json.RawMessage
+	// ID is the event's `id:` field, used as Last-Event-ID on reconnect.
+	ID string
+}
+
+// SFTTranslateStream opens a long-lived connection to /sft/translate:stream
+// and returns a *StreamingResponse delivering incremental translation events
+// (partial results, proof fragments, and a final done/error event).
+func (c *OdinClient) SFTTranslateStream(ctx context.Context, mapID string, sourceData map[string]interface{}, opts ...StreamOption) *StreamingResponse {
+	request := SFTTranslationRequest{
+		MapID:      mapID,
+		SourceData: sourceData,
+	}
+	return c.streamRequest(ctx, http.MethodPost, "/sft/translate:stream", request, opts...)
+}
+
+// ExecuteBridgeProWorkflowStream opens a long-lived connection to
+// /bridge-pro/execute:stream and streams per-record progress for
+// long-running conversions (e.g. batched SWIFT->ISO20022).
+func (c *OdinClient) ExecuteBridgeProWorkflowStream(ctx context.Context, workflowID string, inputData map[string]interface{}, opts ...StreamOption) *StreamingResponse {
+	request := map[string]interface{}{
+		"workflow_id": workflowID,
+		"input_data":  inputData,
+	}
+	return c.streamRequest(ctx, http.MethodPost, "/bridge-pro/execute:stream", request, opts...)
+}
+
+// GetResearchResultsStream long-polls /research/projects/{id}/results:stream,
+// delivering a `progress` event as the run advances and a final `done` event
+// carrying the results. Unlike GetResearchResults, callers can extend the
+// read deadline (via SetReadDeadline or WithReadDeadline) mid-stream instead
+// of being bound by HTTPClient.Timeout or having to re-poll.
+func (c *OdinClient) GetResearchResultsStream(ctx context.Context, projectID string, opts ...StreamOption) *StreamingResponse {
+	return c.streamRequest(ctx, http.MethodGet, "/research/projects/"+projectID+"/results:stream", nil, opts...)
+}
+
+// streamRequest drives an SSE connection, reconnecting with Last-Event-ID on
+// transient failures until ctx is done, the read deadline expires, or a
+// `done`/`error` event arrives.
+func (c *OdinClient) streamRequest(ctx context.Context, method, endpoint string, body interface{}, opts ...StreamOption) *StreamingResponse {
+	ctx, cancel := context.WithCancel(ctx)
+
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	stream := &StreamingResponse{
+		deadlineTimer: newDeadlineTimer(),
+		Events:        events,
+		Errs:          errs,
+		cancel:        cancel,
+	}
+	for _, opt := range opts {
+		opt(stream)
+	}
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+		defer cancel()
+
+		lastEventID := ""
+		retry := 1 * time.Second
+
+		for {
+			resp, err := c.openStream(ctx, method, endpoint, body, lastEventID, stream)
+			if err != nil {
+				select {
+				case errs <- fmt.Errorf("stream connection failed: %v", err):
+				default:
+				}
+				return
+			}
+
+			done, nextRetry, streamErr := pumpSSE(ctx, resp.Body, events, &lastEventID, stream)
+			resp.Body.Close()
+
+			if done {
+				return
+			}
+			if streamErr != nil {
+				select {
+				case errs <- streamErr:
+				default:
+				}
+			}
+
+			if nextRetry > 0 {
+				retry = nextRetry
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-stream.readCancelChan():
+				return
+			case <-time.After(retry):
+			}
+		}
+	}()
+
+	return stream
+}
+
+// openStream issues the initial (or reconnect) HTTP request for a stream,
+// honoring Last-Event-ID when resuming and the stream's write deadline. It
+// builds the request through c.buildRequest, and sends it through
+// c.doRequest when an OHTTP transport is configured, so an AuthProvider or
+// OHTTP transport on the client applies here as it does to the one-shot
+// methods in main.go.
+func (c *OdinClient) openStream(ctx context.Context, method, endpoint string, body interface{}, lastEventID string, stream *StreamingResponse) (*http.Response, error) {
+	req, err := c.buildRequest(ctx, method, endpoint, body, "text/event-stream")
+	if err != nil {
+		return nil, err
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// Without OHTTP, send through a copy of HTTPClient with Timeout
+		// cleared: Timeout would also cut off reading the event stream, which
+		// is bounded by the stream's own read/write deadlines instead.
+		if c.OHTTP == nil {
+			client := *c.HTTPClient
+			client.Timeout = 0
+			resp, err := client.Do(req)
+			done <- result{resp, err}
+			return
+		}
+		resp, err := c.doRequest(req)
+		done <- result{resp, err}
+	}()
+
+	select {
+	case <-stream.writeCancelChan():
+		return nil, fmt.Errorf("write deadline exceeded")
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		if r.resp.StatusCode != http.StatusOK {
+			r.resp.Body.Close()
+			return nil, fmt.Errorf("stream request failed with status: %d", r.resp.StatusCode)
+		}
+		return r.resp, nil
+	}
+}
+
+// pumpSSE reads frames from r per the WHATWG EventSource spec (dispatch on a
+// blank line, `id:` updates lastEventID, `retry:` updates the reconnect
+// backoff) and forwards them to events. It returns done=true once a
+// done/error event closes the logical stream, ctx is canceled, or the
+// stream's read deadline expires. It re-reads stream.readCancelChan() on
+// every loop iteration (via readChangedChan) rather than capturing it once,
+// so a SetReadDeadline call made after pumpSSE has already started takes
+// effect immediately instead of being silently ignored.
+func pumpSSE(ctx context.Context, r io.Reader, events chan<- Event, lastEventID *string, stream *StreamingResponse) (done bool, retry time.Duration, err error) {
+	lines := make(chan string)
+	scanErrs := make(chan error, 1)
+
+	// scanDone tells the scanner goroutine below to give up on delivering
+	// its current line/error once pumpSSE itself has returned (e.g. via
+	// ctx cancellation or a read-deadline expiry) and is no longer
+	// draining lines/scanErrs, so the goroutine doesn't block forever on
+	// an unbuffered send nobody will receive.
+	scanDone := make(chan struct{})
+	defer close(scanDone)
+
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-scanDone:
+				return
+			}
+		}
+		if scanErr := scanner.Err(); scanErr != nil {
+			select {
+			case scanErrs <- scanErr:
+			case <-scanDone:
+			}
+		}
+		close(lines)
+	}()
+
+	var eventType EventType
+	var dataLines []string
+	var id string
+
+	flush := func() (bool, error) {
+		if len(dataLines) == 0 && eventType == "" {
+			return false, nil
+		}
+		if eventType == "" {
+			eventType = EventPartial
+		}
+		if id != "" {
+			*lastEventID = id
+		}
+
+		evt := Event{Type: eventType, ID: id, Data: json.RawMessage(strings.Join(dataLines, "\n"))}
+
+		for {
+			select {
+			case events <- evt:
+			case <-ctx.Done():
+				return true, ctx.Err()
+			case <-stream.readCancelChan():
+				return true, fmt.Errorf("read deadline exceeded")
+			case <-stream.readChangedChan():
+				continue
+			}
+			break
+		}
+
+		stop := eventType == EventDone || eventType == EventError
+		eventType, dataLines, id = "", nil, ""
+		return stop, nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, retry, ctx.Err()
+		case <-stream.readCancelChan():
+			return true, retry, fmt.Errorf("read deadline exceeded")
+		case <-stream.readChangedChan():
+			continue
+		case scanErr := <-scanErrs:
+			return false, retry, fmt.Errorf("stream read failed: %v", scanErr)
+		case line, ok := <-lines:
+			if !ok {
+				return false, retry, fmt.Errorf("stream closed by server")
+			}
+			if line == "" {
+				stop, ferr := flush()
+				if stop || ferr != nil {
+					return stop, retry, ferr
+				}
+				continue
+			}
+
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+
+			switch field {
+			case "event":
+				eventType = EventType(value)
+			case "data":
+				dataLines = append(dataLines, value)
+			case "id":
+				id = value
+			case "retry":
+				if ms, convErr := strconv.Atoi(value); convErr == nil {
+					retry = time.Duration(ms) * time.Millisecond
+				}
+			}
+		}
+	}
+}
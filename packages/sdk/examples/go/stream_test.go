@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestPumpSSEReadDeadlineSetAfterStart verifies that a SetReadDeadline call
+// made after pumpSSE is already blocked waiting for a line still interrupts
+// it, i.e. pumpSSE re-reads the stream's live cancel channel instead of
+// selecting on a snapshot taken before the call.
+func TestPumpSSEReadDeadlineSetAfterStart(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	stream := &StreamingResponse{deadlineTimer: newDeadlineTimer()}
+
+	events := make(chan Event)
+	lastEventID := ""
+	done := make(chan struct{})
+	var pumpErr error
+
+	go func() {
+		_, _, pumpErr = pumpSSE(context.Background(), pr, events, &lastEventID, stream)
+		close(done)
+	}()
+
+	// Give pumpSSE a moment to start blocking on the (empty, never-written-to)
+	// pipe before the deadline is set.
+	time.Sleep(20 * time.Millisecond)
+	stream.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("pumpSSE did not observe a read deadline set after it started")
+	}
+
+	if pumpErr == nil {
+		t.Fatal("expected a read deadline exceeded error, got nil")
+	}
+}
+
+// TestPumpSSEDoesNotLeakScannerGoroutineOnCancel reproduces the scanner
+// goroutine leak: pumpSSE used to return as soon as ctx was canceled without
+// telling its internal scanner goroutine to stop, so that goroutine could
+// block forever on an unbuffered send to "lines" that nothing would ever
+// receive again.
+func TestPumpSSEDoesNotLeakScannerGoroutineOnCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	const iterations = 20
+	for i := 0; i < iterations; i++ {
+		pr, pw := io.Pipe()
+		stopWriting := make(chan struct{})
+
+		go func() {
+			for {
+				select {
+				case <-stopWriting:
+					return
+				default:
+				}
+				if _, err := fmt.Fprintf(pw, "data: %d\n\n", i); err != nil {
+					return
+				}
+			}
+		}()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &StreamingResponse{deadlineTimer: newDeadlineTimer()}
+		events := make(chan Event)
+		lastEventID := ""
+		pumpDone := make(chan struct{})
+
+		go func() {
+			pumpSSE(ctx, pr, events, &lastEventID, stream)
+			close(pumpDone)
+		}()
+
+		// Let pumpSSE observe at least one line, then cancel mid-stream
+		// without draining "events" any further, matching how a caller
+		// that stops reading (e.g. via stream.Close()) behaves.
+		select {
+		case <-events:
+		case <-time.After(time.Second):
+			t.Fatal("pumpSSE never delivered an event")
+		}
+		cancel()
+
+		select {
+		case <-pumpDone:
+		case <-time.After(time.Second):
+			t.Fatal("pumpSSE did not return after ctx cancellation")
+		}
+
+		close(stopWriting)
+		pw.Close()
+		pr.Close()
+	}
+
+	var after int
+	for attempt := 0; attempt < 50; attempt++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		after = runtime.NumGoroutine()
+		if after <= before {
+			break
+		}
+	}
+
+	if after > before {
+		t.Fatalf("goroutine count grew from %d to %d after %d pumpSSE cancellations; scanner goroutines are leaking", before, after, iterations)
+	}
+}